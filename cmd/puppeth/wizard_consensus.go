@@ -0,0 +1,95 @@
+// Copyright 2017 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/core"
+)
+
+// ConsensusWizard lets a consensus engine hook into the genesis wizard
+// without makeGenesis needing to know about it ahead of time. Downstream
+// forks can add their own engine (DPoS, IBFT, Tendermint-style, ...) by
+// implementing this interface and calling RegisterConsensus from an init
+// function, rather than patching makeGenesis in wizard_genesis.go.
+type ConsensusWizard interface {
+	// Name is the label shown in the consensus engine selection menu.
+	Name() string
+
+	// Prompt interactively configures g.Config (and, if the engine pre-funds
+	// accounts such as Alien's self-vote signers, g.Alloc) for this engine.
+	Prompt(w *wizard, g *core.Genesis) error
+
+	// ExtraData computes the genesis extra-data section for this engine. It
+	// is called after Prompt, so it may rely on state Prompt accumulated.
+	ExtraData(g *core.Genesis) []byte
+}
+
+// defaultConsensusName is the Name() of the engine makeGenesis falls back to
+// when the user presses enter without a choice. It's matched by name, not by
+// registration-order index, so reordering or adding engine files can't
+// silently change the default.
+const defaultConsensusName = "Clique - proof-of-authority"
+
+// consensusWizards holds a constructor per registered engine rather than a
+// shared instance, since a wizard such as Clique's accumulates per-genesis
+// state (the signer list) between its Prompt and ExtraData calls.
+var consensusWizards []func() ConsensusWizard
+
+// RegisterConsensus adds a consensus engine to the makeGenesis menu. new
+// must return a fresh ConsensusWizard instance on every call.
+func RegisterConsensus(new func() ConsensusWizard) {
+	consensusWizards = append(consensusWizards, new)
+}
+
+// resolveConsensusEngine prints the consensus engine menu built from the
+// registry, reads the user's choice (defaulting to Clique) and drives the
+// chosen engine's Prompt, returning it so the caller can compute ExtraData
+// once the rest of the genesis is configured.
+func resolveConsensusEngine(w *wizard, g *core.Genesis) (ConsensusWizard, error) {
+	fmt.Println()
+	fmt.Println("Which consensus engine to use? (default = clique)")
+	for i, factory := range consensusWizards {
+		fmt.Printf(" %d. %s\n", i+1, factory().Name())
+	}
+	choice := w.read()
+	idx := -1
+	if choice == "" {
+		for i, factory := range consensusWizards {
+			if factory().Name() == defaultConsensusName {
+				idx = i
+				break
+			}
+		}
+		if idx == -1 {
+			return nil, fmt.Errorf("no consensus engine registered with default name %q", defaultConsensusName)
+		}
+	} else {
+		n, err := strconv.Atoi(choice)
+		if err != nil || n < 1 || n > len(consensusWizards) {
+			return nil, fmt.Errorf("invalid consensus engine choice %q", choice)
+		}
+		idx = n - 1
+	}
+	engine := consensusWizards[idx]()
+	if err := engine.Prompt(w, g); err != nil {
+		return nil, err
+	}
+	return engine, nil
+}
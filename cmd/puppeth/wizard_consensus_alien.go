@@ -0,0 +1,95 @@
+// Copyright 2017 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func init() {
+	RegisterConsensus(func() ConsensusWizard { return new(alienWizard) })
+}
+
+// alienWizard configures a delegated-proof-of-stake genesis. It keeps no
+// state of its own: everything ExtraData needs is already on g.Config.Alien.
+type alienWizard struct{}
+
+func (*alienWizard) Name() string { return "Alien  - delegated-proof-of-stake" }
+
+func (*alienWizard) Prompt(w *wizard, g *core.Genesis) error {
+	g.Difficulty = big.NewInt(1)
+	g.Config.Alien = &params.AlienConfig{
+		Period:           3,
+		Epoch:            201600,
+		MaxSignerCount:   21,
+		MinVoterBalance:  new(big.Int).Mul(big.NewInt(1000), big.NewInt(1e+18)),
+		GenesisTimestamp: uint64(time.Now().Unix()) + (60 * 5), // Add five minutes
+		SelfVoteSigners:  []common.UnprefixedAddress{},
+	}
+	fmt.Println()
+	fmt.Println("How many seconds should blocks take? (default = 3)")
+	g.Config.Alien.Period = uint64(w.readDefaultInt(3))
+
+	fmt.Println()
+	fmt.Println("How many blocks create for one epoch? (default = 201600)")
+	g.Config.Alien.Epoch = uint64(w.readDefaultInt(201600))
+
+	fmt.Println()
+	fmt.Println("What is the max number of signers? (default = 21)")
+	g.Config.Alien.MaxSignerCount = uint64(w.readDefaultInt(21))
+
+	fmt.Println()
+	fmt.Println("What is the minimize balance for valid voter ? (default = 1000 ETH)")
+	g.Config.Alien.MinVoterBalance = new(big.Int).Mul(big.NewInt(int64(w.readDefaultInt(1000))),
+		big.NewInt(1e+18))
+
+	fmt.Println()
+	fmt.Println("How many block reward one block generate ? (default = 10 ETH)")
+	g.Config.Alien.BlockReward = new(big.Int).Mul(big.NewInt(int64(w.readDefaultInt(10))),
+		big.NewInt(1e+18))
+
+	fmt.Println()
+	fmt.Println("How many minutes delay to create first block ? (default = 5 minutes)")
+	g.Config.Alien.GenesisTimestamp = uint64(time.Now().Unix()) + uint64(w.readDefaultInt(5)*60)
+
+	// We also need the initial list of signers
+	fmt.Println()
+	fmt.Println("Which accounts are vote by themselves to seal the block?(least one, those accounts will be auto pre-funded)")
+	for {
+		if address := w.readAddress(); address != nil {
+			g.Config.Alien.SelfVoteSigners = append(g.Config.Alien.SelfVoteSigners, common.UnprefixedAddress(*address))
+			g.Alloc[*address] = core.GenesisAccount{
+				Balance: g.Config.Alien.MinVoterBalance, // just enough to count as a valid voter
+			}
+			continue
+		}
+		if len(g.Config.Alien.SelfVoteSigners) > 0 {
+			break
+		}
+	}
+	return nil
+}
+
+func (*alienWizard) ExtraData(g *core.Genesis) []byte {
+	return make([]byte, 32+65)
+}
@@ -0,0 +1,76 @@
+// Copyright 2017 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func init() {
+	RegisterConsensus(func() ConsensusWizard { return new(cliqueWizard) })
+}
+
+// cliqueWizard configures a proof-of-authority genesis. It remembers the
+// signer list gathered during Prompt so ExtraData can embed it afterwards.
+type cliqueWizard struct {
+	signers []common.Address
+}
+
+func (*cliqueWizard) Name() string { return "Clique - proof-of-authority" }
+
+func (cw *cliqueWizard) Prompt(w *wizard, g *core.Genesis) error {
+	g.Difficulty = big.NewInt(1)
+	g.Config.Clique = &params.CliqueConfig{
+		Period: 15,
+		Epoch:  30000,
+	}
+	fmt.Println()
+	fmt.Println("How many seconds should blocks take? (default = 15)")
+	g.Config.Clique.Period = uint64(w.readDefaultInt(15))
+
+	// We also need the initial list of signers
+	fmt.Println()
+	fmt.Println("Which accounts are allowed to seal? (mandatory at least one)")
+	for {
+		if address := w.readAddress(); address != nil {
+			cw.signers = append(cw.signers, *address)
+			continue
+		}
+		if len(cw.signers) > 0 {
+			break
+		}
+	}
+	sort.Slice(cw.signers, func(i, j int) bool {
+		return bytes.Compare(cw.signers[i][:], cw.signers[j][:]) < 0
+	})
+	return nil
+}
+
+func (cw *cliqueWizard) ExtraData(g *core.Genesis) []byte {
+	extra := make([]byte, 32+len(cw.signers)*common.AddressLength+65)
+	for i, signer := range cw.signers {
+		copy(extra[32+i*common.AddressLength:], signer[:])
+	}
+	return extra
+}
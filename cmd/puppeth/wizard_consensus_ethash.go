@@ -0,0 +1,41 @@
+// Copyright 2017 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func init() {
+	RegisterConsensus(func() ConsensusWizard { return new(ethashWizard) })
+}
+
+// ethashWizard configures a proof-of-work genesis. It has no per-invocation
+// state of its own: the extra-data section is always 32 zero bytes.
+type ethashWizard struct{}
+
+func (*ethashWizard) Name() string { return "Ethash - proof-of-work" }
+
+func (*ethashWizard) Prompt(w *wizard, g *core.Genesis) error {
+	g.Config.Ethash = new(params.EthashConfig)
+	return nil
+}
+
+func (*ethashWizard) ExtraData(g *core.Genesis) []byte {
+	return make([]byte, 32)
+}
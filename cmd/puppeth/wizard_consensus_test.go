@@ -0,0 +1,109 @@
+// Copyright 2017 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bufio"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// fakeConsensusWizard is a minimal ConsensusWizard used to exercise the
+// registry without depending on Ethash/Clique/Alien's own prompts.
+type fakeConsensusWizard struct {
+	prompted bool
+}
+
+func (*fakeConsensusWizard) Name() string { return "Fake - test-only engine" }
+
+func (f *fakeConsensusWizard) Prompt(w *wizard, g *core.Genesis) error {
+	f.prompted = true
+	g.Config.Ethash = new(params.EthashConfig) // stand in for some engine-specific config
+	return nil
+}
+
+func (*fakeConsensusWizard) ExtraData(g *core.Genesis) []byte {
+	return []byte("fake-extra-data")
+}
+
+// scriptedWizard returns a wizard whose reader is pre-loaded with the given
+// newline-separated answers, as if a user had typed them.
+func scriptedWizard(answers ...string) *wizard {
+	return &wizard{in: bufio.NewReader(strings.NewReader(strings.Join(answers, "\n") + "\n"))}
+}
+
+func TestResolveConsensusEngineSelectsRegisteredFake(t *testing.T) {
+	fake := new(fakeConsensusWizard)
+	defer registerTemporaryConsensus(func() ConsensusWizard { return fake })()
+
+	w := scriptedWizard(strconv.Itoa(len(consensusWizards)))
+	genesis := &core.Genesis{Config: new(params.ChainConfig), Alloc: make(core.GenesisAlloc)}
+
+	engine, err := resolveConsensusEngine(w, genesis)
+	if err != nil {
+		t.Fatalf("resolveConsensusEngine returned error: %v", err)
+	}
+	if engine.Name() != fake.Name() {
+		t.Fatalf("expected fake engine to be selected, got %q", engine.Name())
+	}
+	if !fake.prompted {
+		t.Fatalf("expected fake engine's Prompt to be driven by the scripted reader")
+	}
+	if string(engine.ExtraData(genesis)) != "fake-extra-data" {
+		t.Fatalf("unexpected extra-data: %q", engine.ExtraData(genesis))
+	}
+}
+
+func TestResolveConsensusEngineDefaultsToCliqueByName(t *testing.T) {
+	// Register a fake engine ahead of Clique in the registry to prove the
+	// default is resolved by Name(), not by Clique's registration index.
+	before := consensusWizards
+	consensusWizards = append([]func() ConsensusWizard{func() ConsensusWizard { return new(fakeConsensusWizard) }}, consensusWizards...)
+	defer func() { consensusWizards = before }()
+
+	w := scriptedWizard("", "15", "0x1111111111111111111111111111111111111111", "")
+	genesis := &core.Genesis{Config: new(params.ChainConfig), Alloc: make(core.GenesisAlloc)}
+
+	engine, err := resolveConsensusEngine(w, genesis)
+	if err != nil {
+		t.Fatalf("resolveConsensusEngine returned error: %v", err)
+	}
+	if engine.Name() != defaultConsensusName {
+		t.Fatalf("expected default engine %q, got %q", defaultConsensusName, engine.Name())
+	}
+}
+
+func TestResolveConsensusEngineRejectsOutOfRangeChoice(t *testing.T) {
+	w := scriptedWizard(strconv.Itoa(len(consensusWizards) + 1))
+	genesis := &core.Genesis{Config: new(params.ChainConfig), Alloc: make(core.GenesisAlloc)}
+
+	if _, err := resolveConsensusEngine(w, genesis); err == nil {
+		t.Fatalf("expected an error for an out-of-range consensus engine choice")
+	}
+}
+
+// registerTemporaryConsensus appends factory to the registry for the
+// duration of a test and returns a func to restore the previous registry.
+func registerTemporaryConsensus(factory func() ConsensusWizard) func() {
+	before := consensusWizards
+	RegisterConsensus(factory)
+	return func() { consensusWizards = before }
+}
@@ -18,6 +18,9 @@ package main
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -27,9 +30,12 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
@@ -52,129 +58,90 @@ func (w *wizard) makeGenesis() {
 			ConstantinopleBlock: big.NewInt(5),
 			PetersburgBlock:     big.NewInt(6),
 			IstanbulBlock:       big.NewInt(7),
+			MuirGlacierBlock:    big.NewInt(8),
+			BerlinBlock:         big.NewInt(9),
+			LondonBlock:         big.NewInt(10),
+			ArrowGlacierBlock:   big.NewInt(11),
+			GrayGlacierBlock:    big.NewInt(12),
 		},
 	}
-	// Figure out which consensus engine to choose
+	// Figure out which consensus engine to choose, pulling the menu and the
+	// prompt/extra-data logic from whatever engines have registered
+	// themselves via RegisterConsensus.
+	engine, err := resolveConsensusEngine(w, genesis)
+	if err != nil {
+		log.Crit("Failed to configure consensus engine", "err", err)
+	}
+	genesis.ExtraData = engine.ExtraData(genesis)
+	// Let the user pin the post-Istanbul forks to the canonical mainnet
+	// blocks, or configure them (and EIP-1559) by hand.
 	fmt.Println()
-	fmt.Println("Which consensus engine to use? (default = clique)")
-	fmt.Println(" 1. Ethash - proof-of-work")
-	fmt.Println(" 2. Clique - proof-of-authority")
-	fmt.Println(" 3. Alien  - delegated-proof-of-stake")
-
-	choice := w.read()
-	switch {
-	case choice == "1":
-		// In case of ethash, we're pretty much done
-		genesis.Config.Ethash = new(params.EthashConfig)
-		genesis.ExtraData = make([]byte, 32)
-
-	case choice == "" || choice == "2":
-		// In the case of clique, configure the consensus parameters
-		genesis.Difficulty = big.NewInt(1)
-		genesis.Config.Clique = &params.CliqueConfig{
-			Period: 15,
-			Epoch:  30000,
-		}
-		fmt.Println()
-		fmt.Println("How many seconds should blocks take? (default = 15)")
-		genesis.Config.Clique.Period = uint64(w.readDefaultInt(15))
-
-		// We also need the initial list of signers
-		fmt.Println()
-		fmt.Println("Which accounts are allowed to seal? (mandatory at least one)")
-
-		var signers []common.Address
+	fmt.Println("Use canonical mainnet fork blocks for Muir Glacier/Berlin/London/Arrow Glacier/Gray Glacier? (advisable yes)")
+	if w.readDefaultYesNo(true) {
+		genesis.Config.MuirGlacierBlock = big.NewInt(9200000)
+		genesis.Config.BerlinBlock = big.NewInt(12244000)
+		genesis.Config.LondonBlock = big.NewInt(12965000)
+		genesis.Config.ArrowGlacierBlock = big.NewInt(13773000)
+		genesis.Config.GrayGlacierBlock = big.NewInt(15050000)
+	} else {
 		for {
-			if address := w.readAddress(); address != nil {
-				signers = append(signers, *address)
-				continue
-			}
-			if len(signers) > 0 {
-				break
-			}
-		}
-		// Sort the signers and embed into the extra-data section
-		for i := 0; i < len(signers); i++ {
-			for j := i + 1; j < len(signers); j++ {
-				if bytes.Compare(signers[i][:], signers[j][:]) > 0 {
-					signers[i], signers[j] = signers[j], signers[i]
-				}
-			}
-		}
-		genesis.ExtraData = make([]byte, 32+len(signers)*common.AddressLength+65)
-		for i, signer := range signers {
-			copy(genesis.ExtraData[32+i*common.AddressLength:], signer[:])
-		}
-	case choice == "" || choice == "3":
-		// In the case of alien, configure the consensus parameters
-		genesis.Difficulty = big.NewInt(1)
-		genesis.Config.Alien = &params.AlienConfig{
-			Period:           3,
-			Epoch:            201600,
-			MaxSignerCount:   21,
-			MinVoterBalance:  new(big.Int).Mul(big.NewInt(1000), big.NewInt(1e+18)),
-			GenesisTimestamp: uint64(time.Now().Unix()) + (60 * 5), // Add five minutes
-			SelfVoteSigners:  []common.UnprefixedAddress{},
-		}
-		fmt.Println()
-		fmt.Println("How many seconds should blocks take? (default = 3)")
-		genesis.Config.Alien.Period = uint64(w.readDefaultInt(3))
+			fmt.Println()
+			fmt.Printf("Which block should Muir Glacier come into effect? (default = %v)\n", genesis.Config.MuirGlacierBlock)
+			genesis.Config.MuirGlacierBlock = w.readDefaultBigInt(genesis.Config.MuirGlacierBlock)
 
-		fmt.Println()
-		fmt.Println("How many blocks create for one epoch? (default = 201600)")
-		genesis.Config.Alien.Epoch = uint64(w.readDefaultInt(201600))
+			fmt.Println()
+			fmt.Printf("Which block should Berlin come into effect? (default = %v)\n", genesis.Config.BerlinBlock)
+			genesis.Config.BerlinBlock = w.readDefaultBigInt(genesis.Config.BerlinBlock)
 
-		fmt.Println()
-		fmt.Println("What is the max number of signers? (default = 21)")
-		genesis.Config.Alien.MaxSignerCount = uint64(w.readDefaultInt(21))
+			fmt.Println()
+			fmt.Printf("Which block should London come into effect? (default = %v)\n", genesis.Config.LondonBlock)
+			genesis.Config.LondonBlock = w.readDefaultBigInt(genesis.Config.LondonBlock)
 
-		fmt.Println()
-		fmt.Println("What is the minimize balance for valid voter ? (default = 1000 ETH)")
-		genesis.Config.Alien.MinVoterBalance = new(big.Int).Mul(big.NewInt(int64(w.readDefaultInt(1000))),
-			big.NewInt(1e+18))
+			fmt.Println()
+			fmt.Printf("Which block should Arrow Glacier come into effect? (default = %v)\n", genesis.Config.ArrowGlacierBlock)
+			genesis.Config.ArrowGlacierBlock = w.readDefaultBigInt(genesis.Config.ArrowGlacierBlock)
 
-		fmt.Println()
-		fmt.Println("How many block reward one block generate ? (default = 10 ETH)")
-		genesis.Config.Alien.BlockReward = new(big.Int).Mul(big.NewInt(int64(w.readDefaultInt(10))),
-			big.NewInt(1e+18))
+			fmt.Println()
+			fmt.Printf("Which block should Gray Glacier come into effect? (default = %v)\n", genesis.Config.GrayGlacierBlock)
+			genesis.Config.GrayGlacierBlock = w.readDefaultBigInt(genesis.Config.GrayGlacierBlock)
 
+			if err := checkForkOrder(genesis.Config); err != nil {
+				log.Error("Inconsistent fork ordering, please redo", "err", err)
+				continue
+			}
+			break
+		}
+	}
+	// London is EIP-1559 aware, so it needs a base fee. If it's activated at
+	// genesis there's no parent block to derive one from, so ask for it. The
+	// elasticity multiplier and base-fee-change denominator aren't per-chain
+	// configurable in core.Genesis/params.ChainConfig - they're protocol
+	// constants (params.ElasticityMultiplier, params.BaseFeeChangeDenominator).
+	if genesis.Config.LondonBlock != nil && genesis.Config.LondonBlock.Sign() == 0 {
 		fmt.Println()
-		fmt.Println("How many minutes delay to create first block ? (default = 5 minutes)")
-		genesis.Config.Alien.GenesisTimestamp = uint64(time.Now().Unix()) + uint64(w.readDefaultInt(5)*60)
-
-		// We also need the initial list of signers
+		fmt.Println("What should the genesis base fee be, in wei? (default = 1 gwei)")
+		genesis.BaseFee = w.readDefaultBigInt(big.NewInt(params.InitialBaseFee))
+	}
+	// Consensus all set, just ask for initial funds and go
+	fmt.Println()
+	fmt.Println("Import pre-funded accounts from a CSV/JSON file or holder snapshot instead of typing them one by one? (default = no)")
+	if w.readDefaultYesNo(false) {
+		if err := w.importGenesisAlloc(genesis); err != nil {
+			log.Error("Failed to import pre-fund allocation", "err", err)
+		}
+	} else {
 		fmt.Println()
-		fmt.Println("Which accounts are vote by themselves to seal the block?(least one, those accounts will be auto pre-funded)")
+		fmt.Println("Which accounts should be pre-funded? (advisable at least one)")
 		for {
+			// Read the address of the account to fund
 			if address := w.readAddress(); address != nil {
-
-				genesis.Config.Alien.SelfVoteSigners = append(genesis.Config.Alien.SelfVoteSigners, common.UnprefixedAddress(*address))
 				genesis.Alloc[*address] = core.GenesisAccount{
-					Balance: genesis.Config.Alien.MinVoterBalance, // 2^256 / 128 (allow many pre-funds without balance overflows)
+					Balance: new(big.Int).Lsh(big.NewInt(1), 256-7), // 2^256 / 128 (allow many pre-funds without balance overflows)
 				}
 				continue
 			}
-			if len(genesis.Config.Alien.SelfVoteSigners) > 0 {
-				break
-			}
-		}
-
-		genesis.ExtraData = make([]byte, 32+65)
-	default:
-		log.Crit("Invalid consensus engine choice", "choice", choice)
-	}
-	// Consensus all set, just ask for initial funds and go
-	fmt.Println()
-	fmt.Println("Which accounts should be pre-funded? (advisable at least one)")
-	for {
-		// Read the address of the account to fund
-		if address := w.readAddress(); address != nil {
-			genesis.Alloc[*address] = core.GenesisAccount{
-				Balance: new(big.Int).Lsh(big.NewInt(1), 256-7), // 2^256 / 128 (allow many pre-funds without balance overflows)
-			}
-			continue
+			break
 		}
-		break
 	}
 	fmt.Println()
 	fmt.Println("Should the precompile-addresses (0x1 .. 0xff) be pre-funded with 1 wei? (advisable yes)")
@@ -189,13 +156,351 @@ func (w *wizard) makeGenesis() {
 	fmt.Println("Specify your chain/network ID if you want an explicit one (default = random)")
 	genesis.Config.ChainID = new(big.Int).SetUint64(uint64(w.readDefaultInt(rand.Intn(65536))))
 
-	// All done, store the genesis and flush to disk
-	log.Info("Configured new genesis block")
+	// Offer a deterministic mode so multi-party deployments following the
+	// same script can verify they ended up with a byte-identical genesis.
+	fmt.Println()
+	fmt.Println("Make this genesis reproducible from a seed, for independent multi-party verification? (default = no)")
+	if w.readDefaultYesNo(false) {
+		fmt.Println()
+		fmt.Println("Please specify the seed all parties agreed on")
+		seed := w.read()
+
+		if cw, ok := engine.(*cliqueWizard); ok {
+			// Take Timestamp, ChainID and extra-data from the same constructor
+			// downstream tooling uses, so they match what it derives from this
+			// seed and signer set. The rest of the genesis (forks, period,
+			// allocations) stays as configured above.
+			deterministic := MakeGenesisDeterministic(seed, cw.signers)
+			genesis.Timestamp = deterministic.Timestamp
+			genesis.Config.ChainID = deterministic.Config.ChainID
+			genesis.ExtraData = deterministic.ExtraData
+		} else {
+			applyDeterministicSeed(genesis, seed)
+		}
+		fmt.Printf("Deterministic genesis (seed %q) hash: %s\n", seed, genesis.ToBlock(nil).Hash().Hex())
+	}
+
+	// Dry-run the spec before ever writing it to disk, so a broken genesis
+	// gets caught here rather than at node startup. A failure here is almost
+	// always the consensus engine's doing (e.g. Alien with more self-vote
+	// signers than MaxSignerCount), so
+	// let the user redo that step instead of discarding everything else
+	// they've already answered.
+	var report *genesisDryRun
+	for {
+		report, err = dryRunGenesis(genesis)
+		if err == nil {
+			break
+		}
+		log.Error("Genesis spec failed validation, please reconfigure the consensus engine", "err", err)
+		engine, err = resolveConsensusEngine(w, genesis)
+		if err != nil {
+			log.Crit("Failed to configure consensus engine", "err", err)
+		}
+		genesis.ExtraData = engine.ExtraData(genesis)
+	}
+	log.Info("Configured new genesis block", "hash", report.Hash, "root", report.Root, "supply", report.Supply)
 
 	w.conf.Genesis = genesis
 	w.conf.flush()
 }
 
+// MakeGenesisDeterministic builds a Clique genesis for the given signer set
+// whose Timestamp, ChainID and extra-data are derived entirely from seed via
+// SHA-256 and signers, rather than from time.Now()/rand.Intn. It backs the
+// "reproducible genesis" prompt in makeGenesis for the Clique engine, and
+// can also be driven directly by deployment tooling that wants to generate
+// or verify a genesis without running the interactive wizard (there is no
+// "--deterministic" CLI flag for this yet - that belongs in puppeth's
+// cmd/puppeth/main.go entry point, which isn't part of this source tree).
+func MakeGenesisDeterministic(seed string, signers []common.Address) *core.Genesis {
+	sorted := append([]common.Address(nil), signers...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i][:], sorted[j][:]) < 0
+	})
+
+	genesis := &core.Genesis{
+		GasLimit:   4700000,
+		Difficulty: big.NewInt(1),
+		Alloc:      make(core.GenesisAlloc),
+		Config: &params.ChainConfig{
+			HomesteadBlock:      big.NewInt(0),
+			EIP150Block:         big.NewInt(1),
+			EIP155Block:         big.NewInt(2),
+			EIP158Block:         big.NewInt(3),
+			ByzantiumBlock:      big.NewInt(4),
+			ConstantinopleBlock: big.NewInt(5),
+			PetersburgBlock:     big.NewInt(6),
+			IstanbulBlock:       big.NewInt(7),
+			MuirGlacierBlock:    big.NewInt(8),
+			BerlinBlock:         big.NewInt(9),
+			LondonBlock:         big.NewInt(10),
+			ArrowGlacierBlock:   big.NewInt(11),
+			GrayGlacierBlock:    big.NewInt(12),
+			Clique: &params.CliqueConfig{
+				Period: 15,
+				Epoch:  30000,
+			},
+		},
+		ExtraData: make([]byte, 32+len(sorted)*common.AddressLength+65),
+	}
+	for i, signer := range sorted {
+		copy(genesis.ExtraData[32+i*common.AddressLength:], signer[:])
+	}
+	applyDeterministicSeed(genesis, seed)
+	return genesis
+}
+
+// applyDeterministicSeed derives Timestamp, ChainID and (for Alien chains)
+// GenesisTimestamp from seed via SHA-256, replacing whatever time.Now()- or
+// rand.Intn-sourced values the genesis was built with.
+func applyDeterministicSeed(genesis *core.Genesis, seed string) {
+	genesis.Timestamp = deriveSeedUint64(seed, "timestamp") % 1700000000
+	genesis.Config.ChainID = new(big.Int).SetUint64(deriveSeedUint64(seed, "chainid") % 65536)
+	if genesis.Config.Alien != nil {
+		genesis.Config.Alien.GenesisTimestamp = genesis.Timestamp
+	}
+}
+
+// deriveSeedUint64 hashes seed together with label via SHA-256 and returns
+// the first 8 bytes of the digest as a big-endian uint64.
+func deriveSeedUint64(seed, label string) uint64 {
+	h := sha256.Sum256([]byte(seed + ":" + label))
+	return binary.BigEndian.Uint64(h[:8])
+}
+
+// importGenesisAlloc bulk pre-funds a genesis from a local path or http(s)
+// URL. It accepts a CSV of "address,balance_wei" rows, a JSON object matching
+// core.GenesisAlloc, or a holder-snapshot CSV of bare addresses whose
+// balances are looked up at a given block through a user-supplied RPC
+// endpoint. Files are streamed rather than buffered whole so million-account
+// exports don't blow up wizard memory, and duplicate addresses are merged
+// into the last-seen entry instead of inflating the supply twice.
+func (w *wizard) importGenesisAlloc(genesis *core.Genesis) error {
+	fmt.Println()
+	fmt.Println("Where's the allocation file? (local path or http/https url, .csv or .json)")
+	url := w.readURL()
+
+	var reader io.Reader
+	switch url.Scheme {
+	case "http", "https":
+		res, err := http.Get(url.String())
+		if err != nil {
+			return err
+		}
+		defer res.Body.Close()
+		reader = res.Body
+	case "":
+		file, err := os.Open(url.String())
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		reader = file
+	default:
+		return fmt.Errorf("unsupported allocation URL scheme %q", url.Scheme)
+	}
+
+	var (
+		total = new(big.Int)
+		dupes int
+		err   error
+	)
+	if strings.HasSuffix(strings.ToLower(url.Path), ".json") {
+		total, dupes, err = streamJSONAlloc(reader, genesis)
+	} else {
+		fmt.Println()
+		fmt.Println("Is this a holder snapshot with addresses only? If so, which RPC endpoint should balances be fetched from? (blank = file already has balances)")
+		endpoint := w.readDefaultString("")
+
+		block := "latest"
+		if endpoint != "" {
+			fmt.Println()
+			fmt.Println("At which block number should balances be fetched? (default = latest)")
+			block = w.readDefaultString("latest")
+		}
+		total, dupes, err = streamCSVAlloc(reader, genesis, endpoint, block)
+	}
+	if err != nil {
+		return err
+	}
+	log.Info("Allocation import complete", "accounts", len(genesis.Alloc), "duplicates merged", dupes, "total supply (wei)", total)
+	return nil
+}
+
+// streamCSVAlloc decodes an "address,balance_wei" CSV one record at a time.
+// If endpoint is non-empty, the balance column is ignored (it may be absent
+// entirely, as with a bare holder-address snapshot) and looked up instead via
+// eth_getBalance against that RPC endpoint at the given block.
+func streamCSVAlloc(r io.Reader, genesis *core.Genesis, endpoint, block string) (*big.Int, int, error) {
+	total := new(big.Int)
+	dupes := 0
+
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+		if len(record) == 0 || strings.HasPrefix(strings.TrimSpace(record[0]), "#") {
+			continue
+		}
+		raw := strings.TrimSpace(record[0])
+		if !common.IsHexAddress(raw) {
+			return nil, 0, fmt.Errorf("invalid address %q", raw)
+		}
+		if err := validateChecksum(raw); err != nil {
+			return nil, 0, err
+		}
+		addr := common.HexToAddress(raw)
+
+		var balance *big.Int
+		if endpoint == "" {
+			if len(record) < 2 {
+				return nil, 0, fmt.Errorf("missing balance for %s (no RPC endpoint given to look it up)", addr.Hex())
+			}
+			balance, err = parseWei(strings.TrimSpace(record[1]))
+			if err != nil {
+				return nil, 0, err
+			}
+		} else {
+			balance, err = fetchBalance(endpoint, addr, block)
+			if err != nil {
+				return nil, 0, err
+			}
+		}
+		if prior, ok := genesis.Alloc[addr]; ok {
+			dupes++
+			total.Sub(total, prior.Balance)
+		}
+		genesis.Alloc[addr] = core.GenesisAccount{Balance: balance}
+		total.Add(total, balance)
+	}
+	return total, dupes, nil
+}
+
+// streamJSONAlloc decodes a JSON object shaped like core.GenesisAlloc
+// (address -> account) token by token, so the whole file never has to live
+// in memory at once.
+func streamJSONAlloc(r io.Reader, genesis *core.Genesis) (*big.Int, int, error) {
+	total := new(big.Int)
+	dupes := 0
+
+	dec := json.NewDecoder(r)
+	if _, err := dec.Token(); err != nil { // consume opening '{'
+		return nil, 0, err
+	}
+	for dec.More() {
+		keyTok, err := dec.Token()
+		if err != nil {
+			return nil, 0, err
+		}
+		raw, ok := keyTok.(string)
+		if !ok {
+			return nil, 0, fmt.Errorf("malformed allocation key %v", keyTok)
+		}
+		if !common.IsHexAddress(raw) {
+			return nil, 0, fmt.Errorf("invalid address %q", raw)
+		}
+		if err := validateChecksum(raw); err != nil {
+			return nil, 0, err
+		}
+		addr := common.HexToAddress(raw)
+
+		var account core.GenesisAccount
+		if err := dec.Decode(&account); err != nil {
+			return nil, 0, fmt.Errorf("invalid allocation for %s: %v", addr.Hex(), err)
+		}
+		if account.Balance == nil {
+			account.Balance = new(big.Int)
+		}
+		if prior, ok := genesis.Alloc[addr]; ok {
+			dupes++
+			total.Sub(total, prior.Balance)
+		}
+		genesis.Alloc[addr] = account
+		total.Add(total, account.Balance)
+	}
+	if _, err := dec.Token(); err != nil { // consume closing '}'
+		return nil, 0, err
+	}
+	return total, dupes, nil
+}
+
+// validateChecksum rejects addresses that use mixed-case hex (i.e. claim to
+// be EIP-55 checksummed) but don't match the canonical checksum, catching
+// transcription typos in large allocation files early.
+func validateChecksum(raw string) error {
+	hex := strings.TrimPrefix(strings.TrimPrefix(raw, "0x"), "0X")
+	if hex == strings.ToLower(hex) || hex == strings.ToUpper(hex) {
+		return nil // not checksummed, nothing to validate
+	}
+	if "0x"+hex != common.HexToAddress(raw).Hex() {
+		return fmt.Errorf("address %q fails EIP-55 checksum", raw)
+	}
+	return nil
+}
+
+// parseWei parses a decimal or 0x-prefixed hexadecimal wei amount.
+func parseWei(s string) (*big.Int, error) {
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		v, ok := new(big.Int).SetString(s[2:], 16)
+		if !ok {
+			return nil, fmt.Errorf("invalid hex balance %q", s)
+		}
+		return v, nil
+	}
+	v, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid balance %q", s)
+	}
+	return v, nil
+}
+
+// fetchBalance retrieves an account's balance at the given block via a
+// plain eth_getBalance JSON-RPC call against endpoint.
+func fetchBalance(endpoint string, addr common.Address, block string) (*big.Int, error) {
+	req := map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "eth_getBalance",
+		"params":  []interface{}{addr.Hex(), block},
+	}
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	res, err := http.Post(endpoint, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var reply struct {
+		Result string `json:"result"`
+		Error  *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&reply); err != nil {
+		return nil, err
+	}
+	if reply.Error != nil {
+		return nil, fmt.Errorf("eth_getBalance(%s): %s", addr.Hex(), reply.Error.Message)
+	}
+	balance, ok := new(big.Int).SetString(strings.TrimPrefix(reply.Result, "0x"), 16)
+	if !ok {
+		return nil, fmt.Errorf("invalid eth_getBalance response %q for %s", reply.Result, addr.Hex())
+	}
+	return balance, nil
+}
+
 // importGenesis imports a Geth genesis spec into puppeth.
 func (w *wizard) importGenesis() {
 	// Request the genesis JSON spec URL from the user
@@ -231,18 +536,294 @@ func (w *wizard) importGenesis() {
 		log.Error("Unsupported genesis URL scheme", "scheme", url.Scheme)
 		return
 	}
-	// Parse the genesis file and inject it successful
-	var genesis core.Genesis
-	if err := json.NewDecoder(reader).Decode(&genesis); err != nil {
+	// Buffer the spec so we can sniff its format before committing to a decoder
+	blob, err := ioutil.ReadAll(reader)
+	if err != nil {
+		log.Error("Failed to read genesis spec", "err", err)
+		return
+	}
+	genesis, err := parseGenesisSpec(blob)
+	if err != nil {
 		log.Error("Invalid genesis spec: %v", err)
 		return
 	}
-	log.Info("Imported genesis block")
+	// Converted Parity/Aleth chainspecs are untrusted input just like any
+	// hand-typed genesis, so run them through the same dry-run validation
+	// makeGenesis does before ever flushing them to disk.
+	report, err := dryRunGenesis(genesis)
+	if err != nil {
+		log.Error("Genesis spec failed validation, not imported", "err", err)
+		return
+	}
+	log.Info("Imported genesis block", "hash", report.Hash, "root", report.Root, "supply", report.Supply)
 
-	w.conf.Genesis = &genesis
+	w.conf.Genesis = genesis
 	w.conf.flush()
 }
 
+// parseGenesisSpec sniffs the top-level keys of an arbitrary genesis/chainspec
+// JSON blob and decodes it into a core.Genesis, transparently converting
+// Parity/OpenEthereum `ChainSpec` and Aleth (cpp-ethereum) config files into
+// the Geth representation. Geth's own genesis format is tried last so it
+// remains the default when the format can't be determined.
+func parseGenesisSpec(blob []byte) (*core.Genesis, error) {
+	var probe map[string]json.RawMessage
+	if err := json.Unmarshal(blob, &probe); err != nil {
+		return nil, err
+	}
+	switch {
+	case probe["engine"] != nil && probe["accounts"] != nil:
+		var spec parityChainSpec
+		if err := json.Unmarshal(blob, &spec); err != nil {
+			return nil, fmt.Errorf("invalid parity chainspec: %v", err)
+		}
+		return spec.convert()
+
+	case probe["sealEngine"] != nil && probe["accounts"] != nil:
+		var spec alethGenesisSpec
+		if err := json.Unmarshal(blob, &spec); err != nil {
+			return nil, fmt.Errorf("invalid aleth genesis: %v", err)
+		}
+		return spec.convert()
+
+	default:
+		genesis := new(core.Genesis)
+		if err := json.Unmarshal(blob, genesis); err != nil {
+			return nil, err
+		}
+		return genesis, nil
+	}
+}
+
+// parityChainSpec is a trimmed down mirror of Parity/OpenEthereum's ChainSpec
+// JSON format, covering the fields needed to reconstruct a core.Genesis.
+type parityChainSpec struct {
+	Name   string `json:"name"`
+	Engine struct {
+		Ethash struct {
+			Params struct {
+				HomesteadTransition *hexutil.Uint64 `json:"homesteadTransition"`
+				EIP150Transition    *hexutil.Uint64 `json:"eip150Transition"`
+				EIP160Transition    *hexutil.Uint64 `json:"eip160Transition"`
+				EIP161abcTransition *hexutil.Uint64 `json:"eip161abcTransition"`
+				EIP649Transition    *hexutil.Uint64 `json:"eip649Transition"`
+				EIP1014Transition   *hexutil.Uint64 `json:"eip1014Transition"`
+				EIP1052Transition   *hexutil.Uint64 `json:"eip1052Transition"`
+				EIP1283Transition   *hexutil.Uint64 `json:"eip1283Transition"`
+				EIP1344Transition   *hexutil.Uint64 `json:"eip1344Transition"`
+				EIP1884Transition   *hexutil.Uint64 `json:"eip1884Transition"`
+				EIP2028Transition   *hexutil.Uint64 `json:"eip2028Transition"`
+			} `json:"params"`
+		} `json:"Ethash"`
+		Clique struct {
+			Params struct {
+				Period hexutil.Uint64 `json:"period"`
+				Epoch  hexutil.Uint64 `json:"epoch"`
+			} `json:"params"`
+		} `json:"Clique"`
+		InstantSeal *struct{} `json:"instantSeal"`
+	} `json:"engine"`
+	Params struct {
+		NetworkID        *hexutil.Big    `json:"networkID"`
+		ChainID          *hexutil.Big    `json:"chainID"`
+		EIP155Transition *hexutil.Uint64 `json:"eip155Transition"`
+	} `json:"params"`
+	Genesis struct {
+		Seal struct {
+			Ethereum struct {
+				Nonce   hexutil.Bytes `json:"nonce"`
+				MixHash hexutil.Bytes `json:"mixHash"`
+			} `json:"ethereum"`
+		} `json:"seal"`
+		Difficulty *hexutil.Big   `json:"difficulty"`
+		GasLimit   *hexutil.Big   `json:"gasLimit"`
+		Timestamp  hexutil.Uint64 `json:"timestamp"`
+		ExtraData  hexutil.Bytes  `json:"extraData"`
+	} `json:"genesis"`
+	Accounts map[common.UnprefixedAddress]*parityChainSpecAccount `json:"accounts"`
+}
+
+// parityChainSpecAccount is the per-account allocation entry of a Parity
+// ChainSpec, mirroring core.GenesisAccount but with hex-encoded fields.
+type parityChainSpecAccount struct {
+	Balance *hexutil.Big                `json:"balance"`
+	Nonce   hexutil.Uint64              `json:"nonce"`
+	Code    hexutil.Bytes               `json:"code"`
+	Storage map[common.Hash]common.Hash `json:"storage"`
+}
+
+// convert maps a Parity ChainSpec onto a core.Genesis, translating the
+// consensus engine and its fork-activation params into params.ChainConfig.
+// Fields that have no Geth equivalent (e.g. Parity-specific seal rounds) are
+// dropped rather than aborting the import. Only instantSeal logs a warning;
+// other dropped fields are not reported.
+func (spec *parityChainSpec) convert() (*core.Genesis, error) {
+	config := &params.ChainConfig{ChainID: spec.Params.ChainID}
+
+	switch {
+	case spec.Engine.Clique.Params.Period != 0 || spec.Engine.Clique.Params.Epoch != 0:
+		config.Clique = &params.CliqueConfig{
+			Period: uint64(spec.Engine.Clique.Params.Period),
+			Epoch:  uint64(spec.Engine.Clique.Params.Epoch),
+		}
+	case spec.Engine.InstantSeal != nil:
+		config.Clique = &params.CliqueConfig{Period: 0, Epoch: 30000}
+		log.Warn("Parity instantSeal has no direct Geth equivalent, approximating with Clique(period=0)")
+	default:
+		config.Ethash = new(params.EthashConfig)
+	}
+
+	ethash := spec.Engine.Ethash.Params
+	config.HomesteadBlock = uint64PtrToBig(ethash.HomesteadTransition)
+	config.EIP150Block = uint64PtrToBig(ethash.EIP150Transition)
+	config.EIP155Block = uint64PtrToBig(spec.Params.EIP155Transition)
+	config.EIP158Block = uint64PtrToBig(ethash.EIP161abcTransition)
+	config.ByzantiumBlock = uint64PtrToBig(ethash.EIP649Transition)
+	config.ConstantinopleBlock = uint64PtrToBig(ethash.EIP1283Transition)
+	config.IstanbulBlock = uint64PtrToBig(ethash.EIP1344Transition)
+
+	genesis := &core.Genesis{
+		Config:     config,
+		Nonce:      decodeNonce(spec.Genesis.Seal.Ethereum.Nonce),
+		Timestamp:  uint64(spec.Genesis.Timestamp),
+		ExtraData:  spec.Genesis.ExtraData,
+		GasLimit:   bigPtrToUint64(spec.Genesis.GasLimit),
+		Difficulty: bigPtrOrZero(spec.Genesis.Difficulty),
+		Mixhash:    common.BytesToHash(spec.Genesis.Seal.Ethereum.MixHash),
+		Alloc:      make(core.GenesisAlloc, len(spec.Accounts)),
+	}
+	for addr, account := range spec.Accounts {
+		if account == nil {
+			continue
+		}
+		alloc := core.GenesisAccount{
+			Code:  account.Code,
+			Nonce: uint64(account.Nonce),
+		}
+		if account.Balance != nil {
+			alloc.Balance = account.Balance.ToInt()
+		} else {
+			alloc.Balance = new(big.Int)
+		}
+		if len(account.Storage) > 0 {
+			alloc.Storage = account.Storage
+		}
+		genesis.Alloc[common.Address(addr)] = alloc
+	}
+	return genesis, nil
+}
+
+// alethGenesisSpec is a trimmed down mirror of Aleth's (cpp-ethereum) genesis
+// config format. It shares its `params`/`genesis`/`accounts` shape with
+// Parity's ChainSpec but advertises its consensus engine via a top-level
+// `sealEngine` string instead of a nested `engine` object.
+type alethGenesisSpec struct {
+	SealEngine string `json:"sealEngine"`
+	Params     struct {
+		ChainID            *hexutil.Big    `json:"chainID"`
+		HomesteadForkBlock *hexutil.Uint64 `json:"homesteadForkBlock"`
+		EIP150ForkBlock    *hexutil.Uint64 `json:"EIP150ForkBlock"`
+		EIP158ForkBlock    *hexutil.Uint64 `json:"EIP158ForkBlock"`
+		ByzantiumForkBlock *hexutil.Uint64 `json:"byzantiumForkBlock"`
+	} `json:"params"`
+	Genesis struct {
+		Nonce      hexutil.Bytes  `json:"nonce"`
+		MixHash    hexutil.Bytes  `json:"mixHash"`
+		Difficulty *hexutil.Big   `json:"difficulty"`
+		GasLimit   *hexutil.Big   `json:"gasLimit"`
+		Timestamp  hexutil.Uint64 `json:"timestamp"`
+		ExtraData  hexutil.Bytes  `json:"extraData"`
+	} `json:"genesis"`
+	Accounts map[common.UnprefixedAddress]*parityChainSpecAccount `json:"accounts"`
+}
+
+// convert maps an Aleth genesis config onto a core.Genesis. Unmapped Aleth
+// seal engines (e.g. NoProof used for Aleth's own test networks) fall back to
+// Ethash, with a warning, since Geth has no equivalent no-op engine.
+func (spec *alethGenesisSpec) convert() (*core.Genesis, error) {
+	config := &params.ChainConfig{
+		ChainID:        spec.Params.ChainID,
+		HomesteadBlock: uint64PtrToBig(spec.Params.HomesteadForkBlock),
+		EIP150Block:    uint64PtrToBig(spec.Params.EIP150ForkBlock),
+		EIP155Block:    uint64PtrToBig(spec.Params.EIP158ForkBlock),
+		EIP158Block:    uint64PtrToBig(spec.Params.EIP158ForkBlock),
+		ByzantiumBlock: uint64PtrToBig(spec.Params.ByzantiumForkBlock),
+	}
+	switch spec.SealEngine {
+	case "Ethash":
+		config.Ethash = new(params.EthashConfig)
+	default:
+		config.Ethash = new(params.EthashConfig)
+		log.Warn("Unmapped Aleth seal engine, defaulting to Ethash", "sealEngine", spec.SealEngine)
+	}
+	genesis := &core.Genesis{
+		Config:     config,
+		Nonce:      decodeNonce(spec.Genesis.Nonce),
+		Timestamp:  uint64(spec.Genesis.Timestamp),
+		ExtraData:  spec.Genesis.ExtraData,
+		GasLimit:   bigPtrToUint64(spec.Genesis.GasLimit),
+		Difficulty: bigPtrOrZero(spec.Genesis.Difficulty),
+		Mixhash:    common.BytesToHash(spec.Genesis.MixHash),
+		Alloc:      make(core.GenesisAlloc, len(spec.Accounts)),
+	}
+	for addr, account := range spec.Accounts {
+		if account == nil {
+			continue
+		}
+		alloc := core.GenesisAccount{Code: account.Code, Nonce: uint64(account.Nonce)}
+		if account.Balance != nil {
+			alloc.Balance = account.Balance.ToInt()
+		} else {
+			alloc.Balance = new(big.Int)
+		}
+		if len(account.Storage) > 0 {
+			alloc.Storage = account.Storage
+		}
+		genesis.Alloc[common.Address(addr)] = alloc
+	}
+	return genesis, nil
+}
+
+// uint64PtrToBig converts an optional hex-encoded fork transition block into
+// the *big.Int representation used by params.ChainConfig, leaving unset
+// transitions as nil so they're reported as "never activated" like Geth's own
+// zero-value forks.
+func uint64PtrToBig(v *hexutil.Uint64) *big.Int {
+	if v == nil {
+		return nil
+	}
+	return new(big.Int).SetUint64(uint64(*v))
+}
+
+// decodeNonce reads a little-endian block nonce out of a raw byte slice,
+// treating a short or absent slice (e.g. a Parity instantSeal spec, whose
+// seal carries no Ethereum-style nonce at all) as a nonce of zero rather than
+// panicking on the out-of-range index.
+func decodeNonce(raw hexutil.Bytes) uint64 {
+	if len(raw) < 8 {
+		return 0
+	}
+	return binary.LittleEndian.Uint64(raw)
+}
+
+// bigPtrToUint64 reads an optional hex-encoded integer as a uint64, treating
+// an omitted field as zero instead of dereferencing a nil *hexutil.Big.
+func bigPtrToUint64(v *hexutil.Big) uint64 {
+	if v == nil {
+		return 0
+	}
+	return v.ToInt().Uint64()
+}
+
+// bigPtrOrZero reads an optional hex-encoded integer as a *big.Int, treating
+// an omitted field as zero instead of dereferencing a nil *hexutil.Big.
+func bigPtrOrZero(v *hexutil.Big) *big.Int {
+	if v == nil {
+		return new(big.Int)
+	}
+	return v.ToInt()
+}
+
 // manageGenesis permits the modification of chain configuration parameters in
 // a genesis config and the export of the entire genesis spec.
 func (w *wizard) manageGenesis() {
@@ -290,20 +871,57 @@ func (w *wizard) manageGenesis() {
 		fmt.Printf("Which block should Istanbul come into effect? (default = %v)\n", w.conf.Genesis.Config.IstanbulBlock)
 		w.conf.Genesis.Config.IstanbulBlock = w.readDefaultBigInt(w.conf.Genesis.Config.IstanbulBlock)
 
+		fmt.Println()
+		fmt.Printf("Which block should Muir Glacier come into effect? (default = %v)\n", w.conf.Genesis.Config.MuirGlacierBlock)
+		w.conf.Genesis.Config.MuirGlacierBlock = w.readDefaultBigInt(w.conf.Genesis.Config.MuirGlacierBlock)
+
+		fmt.Println()
+		fmt.Printf("Which block should Berlin come into effect? (default = %v)\n", w.conf.Genesis.Config.BerlinBlock)
+		w.conf.Genesis.Config.BerlinBlock = w.readDefaultBigInt(w.conf.Genesis.Config.BerlinBlock)
+
+		fmt.Println()
+		fmt.Printf("Which block should London come into effect? (default = %v)\n", w.conf.Genesis.Config.LondonBlock)
+		w.conf.Genesis.Config.LondonBlock = w.readDefaultBigInt(w.conf.Genesis.Config.LondonBlock)
+		if w.conf.Genesis.Config.LondonBlock != nil && w.conf.Genesis.Config.LondonBlock.Sign() == 0 {
+			fmt.Println()
+			fmt.Printf("What should the genesis base fee be, in wei? (default = %v)\n", w.conf.Genesis.BaseFee)
+			w.conf.Genesis.BaseFee = w.readDefaultBigInt(w.conf.Genesis.BaseFee)
+		}
+
+		fmt.Println()
+		fmt.Printf("Which block should Arrow Glacier come into effect? (default = %v)\n", w.conf.Genesis.Config.ArrowGlacierBlock)
+		w.conf.Genesis.Config.ArrowGlacierBlock = w.readDefaultBigInt(w.conf.Genesis.Config.ArrowGlacierBlock)
+
+		fmt.Println()
+		fmt.Printf("Which block should Gray Glacier come into effect? (default = %v)\n", w.conf.Genesis.Config.GrayGlacierBlock)
+		w.conf.Genesis.Config.GrayGlacierBlock = w.readDefaultBigInt(w.conf.Genesis.Config.GrayGlacierBlock)
+
+		report, err := dryRunGenesis(w.conf.Genesis)
+		if err != nil {
+			log.Error("Genesis spec failed validation, configuration not saved", "err", err)
+			return
+		}
+
 		out, _ := json.MarshalIndent(w.conf.Genesis.Config, "", "  ")
 		fmt.Printf("Chain configuration updated:\n\n%s\n", out)
+		log.Info("Genesis spec validated", "hash", report.Hash, "root", report.Root, "supply", report.Supply)
 
 		w.conf.flush()
 
 	case "2":
 		// Save whatever genesis configuration we currently have
+		report, err := dryRunGenesis(w.conf.Genesis)
+		if err != nil {
+			log.Error("Genesis spec failed validation", "err", err)
+			return
+		}
 		fmt.Println()
 		fmt.Printf("Which file to save the genesis into? (default = %s.json)\n", w.network)
 		out, _ := json.MarshalIndent(w.conf.Genesis, "", "  ")
 		if err := ioutil.WriteFile(w.readDefaultString(fmt.Sprintf("%s.json", w.network)), out, 0644); err != nil {
 			log.Error("Failed to save genesis file", "err", err)
 		}
-		log.Info("Exported existing genesis block")
+		log.Info("Exported existing genesis block", "hash", report.Hash)
 
 	case "3":
 		// Make sure we don't have any services running
@@ -321,6 +939,42 @@ func (w *wizard) manageGenesis() {
 	}
 }
 
+// checkForkOrder verifies that the configured fork blocks activate in their
+// canonical order (e.g. a chain can't enable London before Berlin). Forks
+// that are unset (nil, i.e. never activated) are skipped.
+func checkForkOrder(config *params.ChainConfig) error {
+	type fork struct {
+		name  string
+		block *big.Int
+	}
+	forks := []fork{
+		{"homesteadBlock", config.HomesteadBlock},
+		{"eip150Block", config.EIP150Block},
+		{"eip155Block", config.EIP155Block},
+		{"eip158Block", config.EIP158Block},
+		{"byzantiumBlock", config.ByzantiumBlock},
+		{"constantinopleBlock", config.ConstantinopleBlock},
+		{"petersburgBlock", config.PetersburgBlock},
+		{"istanbulBlock", config.IstanbulBlock},
+		{"muirGlacierBlock", config.MuirGlacierBlock},
+		{"berlinBlock", config.BerlinBlock},
+		{"londonBlock", config.LondonBlock},
+		{"arrowGlacierBlock", config.ArrowGlacierBlock},
+		{"grayGlacierBlock", config.GrayGlacierBlock},
+	}
+	var last fork
+	for _, cur := range forks {
+		if cur.block == nil {
+			continue
+		}
+		if last.block != nil && last.block.Cmp(cur.block) > 0 {
+			return fmt.Errorf("unsupported fork ordering: %s (%v) enabled after %s (%v)", last.name, last.block, cur.name, cur.block)
+		}
+		last = cur
+	}
+	return nil
+}
+
 // saveGenesis JSON encodes an arbitrary genesis spec into a pre-defined file.
 func saveGenesis(folder, network, client string, spec interface{}) {
 	path := filepath.Join(folder, fmt.Sprintf("%s-%s.json", network, client))
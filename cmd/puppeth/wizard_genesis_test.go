@@ -0,0 +1,138 @@
+// Copyright 2017 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestMakeGenesisDeterministicIsReproducible(t *testing.T) {
+	signers := []common.Address{
+		common.HexToAddress("0x2222222222222222222222222222222222222222"),
+		common.HexToAddress("0x1111111111111111111111111111111111111111"),
+	}
+	a := MakeGenesisDeterministic("shared-seed", signers)
+	b := MakeGenesisDeterministic("shared-seed", signers)
+
+	if a.ToBlock(nil).Hash() != b.ToBlock(nil).Hash() {
+		t.Fatalf("two genesis built from the same seed and signers produced different hashes")
+	}
+	if err := checkConsensusSanity(a); err != nil {
+		t.Fatalf("deterministic genesis failed consensus sanity check: %v", err)
+	}
+	wantExtra := 32 + len(signers)*common.AddressLength + 65
+	if len(a.ExtraData) != wantExtra {
+		t.Fatalf("extra-data length = %d, want %d", len(a.ExtraData), wantExtra)
+	}
+}
+
+func TestMakeGenesisDeterministicVariesWithSeed(t *testing.T) {
+	signers := []common.Address{common.HexToAddress("0x1111111111111111111111111111111111111111")}
+
+	a := MakeGenesisDeterministic("seed-one", signers)
+	b := MakeGenesisDeterministic("seed-two", signers)
+
+	if a.ToBlock(nil).Hash() == b.ToBlock(nil).Hash() {
+		t.Fatalf("different seeds produced the same genesis hash")
+	}
+}
+
+func TestValidateChecksumAcceptsUnprefixedChecksummedAddress(t *testing.T) {
+	addr := common.HexToAddress("0xde0b295669a9fd93d5f28d9ec85e40f4cb697bae").Hex()
+	unprefixed := strings.TrimPrefix(addr, "0x")
+	if err := validateChecksum(unprefixed); err != nil {
+		t.Fatalf("validateChecksum rejected a correctly checksummed, unprefixed address: %v", err)
+	}
+}
+
+func TestValidateChecksumRejectsBadChecksum(t *testing.T) {
+	addr := common.HexToAddress("0xde0b295669a9fd93d5f28d9ec85e40f4cb697bae").Hex()
+
+	runes := []rune(addr)
+	flipped := false
+	for i, r := range runes {
+		switch {
+		case r >= 'a' && r <= 'f':
+			runes[i] = r - ('a' - 'A')
+			flipped = true
+		case r >= 'A' && r <= 'F':
+			runes[i] = r + ('a' - 'A')
+			flipped = true
+		}
+		if flipped {
+			break
+		}
+	}
+	if !flipped {
+		t.Fatalf("test address has no checksummed letter to flip")
+	}
+	bad := string(runes)
+
+	if err := validateChecksum(bad); err == nil {
+		t.Fatalf("validateChecksum accepted a bad checksum")
+	}
+}
+
+func TestParseGenesisSpecParityForkOrder(t *testing.T) {
+	blob := []byte(`{
+		"name": "test",
+		"engine": {"Ethash": {"params": {"eip150Transition": "0x2", "eip161abcTransition": "0x3"}}},
+		"params": {"chainID": "0x1", "eip155Transition": "0x3"},
+		"genesis": {"seal": {"ethereum": {"nonce": "0x0000000000000042", "mixHash": "0x00"}}, "difficulty": "0x1", "gasLimit": "0x47b760", "timestamp": "0x0", "extraData": "0x00"},
+		"accounts": {}
+	}`)
+	genesis, err := parseGenesisSpec(blob)
+	if err != nil {
+		t.Fatalf("parseGenesisSpec returned error: %v", err)
+	}
+	if genesis.Config.EIP150Block.Uint64() != 2 {
+		t.Fatalf("EIP150Block = %v, want 2", genesis.Config.EIP150Block)
+	}
+	if genesis.Config.EIP155Block.Uint64() != 3 {
+		t.Fatalf("EIP155Block = %v, want 3", genesis.Config.EIP155Block)
+	}
+	if genesis.Config.EIP158Block.Uint64() != 3 {
+		t.Fatalf("EIP158Block = %v, want 3", genesis.Config.EIP158Block)
+	}
+}
+
+func TestParseGenesisSpecAlethForkOrder(t *testing.T) {
+	blob := []byte(`{
+		"sealEngine": "Ethash",
+		"params": {"chainID": "0x1", "homesteadForkBlock": "0x1", "EIP150ForkBlock": "0x2", "EIP158ForkBlock": "0x3", "byzantiumForkBlock": "0x4"},
+		"genesis": {"nonce": "0x0000000000000042", "mixHash": "0x00", "difficulty": "0x1", "gasLimit": "0x47b760", "timestamp": "0x0", "extraData": "0x00"},
+		"accounts": {}
+	}`)
+	genesis, err := parseGenesisSpec(blob)
+	if err != nil {
+		t.Fatalf("parseGenesisSpec returned error: %v", err)
+	}
+	if genesis.Config.EIP150Block.Uint64() != 2 {
+		t.Fatalf("EIP150Block = %v, want 2", genesis.Config.EIP150Block)
+	}
+	// EIP155 (replay protection) activates with Spurious Dragon (EIP158), not
+	// Tangerine Whistle (EIP150) - they must not collapse onto the same field.
+	if genesis.Config.EIP155Block.Uint64() != 3 {
+		t.Fatalf("EIP155Block = %v, want 3 (Spurious Dragon), not EIP150's block", genesis.Config.EIP155Block)
+	}
+	if genesis.Config.EIP158Block.Uint64() != 3 {
+		t.Fatalf("EIP158Block = %v, want 3", genesis.Config.EIP158Block)
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2017 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+)
+
+// genesisDryRun is the result of executing a genesis spec's allocation into
+// a throwaway state trie, without ever touching the real database.
+type genesisDryRun struct {
+	Hash   common.Hash // Genesis block hash
+	Root   common.Hash // State root after applying Alloc
+	Supply *big.Int    // Sum of every pre-allocated balance
+}
+
+// dryRunGenesis sanity-checks a genesis spec and replays it via
+// core.Genesis.ToBlock, so callers can report the resulting hash, state root
+// and total supply before the spec is ever flushed to disk. It returns an
+// error describing the first problem found instead of silently producing a
+// broken spec.
+//
+// ToBlock already applies Alloc into a fresh state trie and computes the
+// root, so that's reused directly rather than replaying Alloc a second time
+// by hand, which could silently drift from what ToBlock actually commits.
+func dryRunGenesis(genesis *core.Genesis) (*genesisDryRun, error) {
+	if err := checkForkOrder(genesis.Config); err != nil {
+		return nil, err
+	}
+	if err := checkConsensusSanity(genesis); err != nil {
+		return nil, err
+	}
+	block := genesis.ToBlock(nil)
+
+	supply := new(big.Int)
+	for _, account := range genesis.Alloc {
+		supply.Add(supply, account.Balance)
+	}
+	return &genesisDryRun{Hash: block.Hash(), Root: block.Root(), Supply: supply}, nil
+}
+
+// checkConsensusSanity verifies the consensus-specific fields that
+// checkForkOrder doesn't cover: Clique's extra-data must be able to hold a
+// positive number of signers, and Alien's self-vote signer set must fit
+// within its own MaxSignerCount.
+func checkConsensusSanity(genesis *core.Genesis) error {
+	switch {
+	case genesis.Config.Clique != nil:
+		extra := len(genesis.ExtraData) - 32 - 65
+		if extra <= 0 || extra%common.AddressLength != 0 {
+			return fmt.Errorf("clique extra-data is %d bytes, want 32 + N*%d + 65 for a positive number of signers N", len(genesis.ExtraData), common.AddressLength)
+		}
+	case genesis.Config.Alien != nil:
+		alien := genesis.Config.Alien
+		if alien.MaxSignerCount == 0 {
+			return fmt.Errorf("alien MaxSignerCount must be positive")
+		}
+		if uint64(len(alien.SelfVoteSigners)) > alien.MaxSignerCount {
+			return fmt.Errorf("alien has %d self-vote signers but MaxSignerCount is only %d", len(alien.SelfVoteSigners), alien.MaxSignerCount)
+		}
+	}
+	return nil
+}